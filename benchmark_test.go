@@ -0,0 +1,98 @@
+package constext
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// These benchmarks are modeled on the stdlib's own src/context/benchmark_test.go,
+// adapted to exercise Cons/ConsAll construction, cancellation, and Value
+// lookups instead of the stdlib's With* constructors.
+
+func BenchmarkConsAll(b *testing.B) {
+	for _, n := range []int{2, 4, 8, 16, 32} {
+		n := n
+		b.Run(fmt.Sprintf("%d-parents", n), func(b *testing.B) {
+			ctxs := make([]context.Context, n)
+			for i := range ctxs {
+				ctxs[i] = context.Background()
+			}
+
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, cancel := ConsAll(ctxs...)
+				cancel()
+			}
+		})
+	}
+}
+
+func BenchmarkConsCancel(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		parent, parentCancel := context.WithCancel(context.Background())
+		cc, _ := Cons(parent, context.Background())
+		parentCancel()
+		<-cc.Done()
+	}
+}
+
+// BenchmarkCommonParentCancel mirrors the stdlib benchmark of the same name:
+// many goroutines each derive a cancelable child from one shared constext and
+// immediately cancel it, measuring contention on the shared parent.
+func BenchmarkCommonParentCancel(b *testing.B) {
+	root := context.WithValue(context.Background(), "key", "value")
+	other, otherCancel := context.WithCancel(context.Background())
+	defer otherCancel()
+	shared, sharedCancel := Cons(root, other)
+	defer sharedCancel()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			ctx, cancel := context.WithCancel(shared)
+			cancel()
+			<-ctx.Done()
+		}
+	})
+}
+
+func BenchmarkValueLookupShallow(b *testing.B) {
+	c1 := context.WithValue(context.Background(), "key", "value")
+	cc, _ := Cons(c1, context.Background())
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		cc.Value("key")
+	}
+}
+
+// BenchmarkValueLookupDeep nests Cons calls 32 deep before the key, to
+// measure how Value scales with tree depth.
+func BenchmarkValueLookupDeep(b *testing.B) {
+	ctx := context.WithValue(context.Background(), "key", "value")
+	for i := 0; i < 32; i++ {
+		ctx, _ = Cons(ctx, context.Background())
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ctx.Value("key")
+	}
+}
+
+func BenchmarkWithTimeout(b *testing.B) {
+	c1 := context.Background()
+	c2, cancel := context.WithTimeout(context.Background(), 1*time.Hour)
+	defer cancel()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, cancel := Cons(c1, c2)
+		cancel()
+	}
+}
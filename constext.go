@@ -5,27 +5,33 @@ package constext
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 type constext struct {
-	car, cdr context.Context
-	done     chan struct{} // chan closed on cancelFunc() call, or parent done
-	mu       sync.Mutex    // protects timer and err
-	timer    *time.Timer   // if either parent has a deadline
-	err      error         // err set on cancel or timeout
+	parents   []context.Context
+	done      chan struct{}         // chan closed on cancelFunc() call, or parent done
+	closeOnce sync.Once             // guards the actual close(done)
+	err       atomic.Pointer[error] // err set on cancel or timeout
+
+	restMu     sync.Mutex         // guards rest/restCancel, for Parents()
+	rest       context.Context    // lazily-built constext of parents[1:], for Parents()
+	restCancel context.CancelFunc // cancels rest once cc itself is canceled
 }
 
 // Cons takes two Contexts and combines them into a pair, conjoining their
 // behavior:
 //
-//  - If either parent context is canceled, the constext is canceled. The err is
-//  set to whatever the err of the parent that was canceled.
-//  - If either parent has a deadline, the constext uses that same deadline. If
-//  both have a deadline, it uses the sooner/lesser one.
-//  - Values from both parents are unioned together. When a key is present in
-//  both parent trees, the left (first) context supercedes the right (second).
+//   - If either parent context is canceled, the constext is canceled. The err is
+//     set to whatever the err of the parent that was canceled.
+//   - If either parent has a deadline, the constext uses that same deadline. If
+//     both have a deadline, it uses the sooner/lesser one.
+//   - Values from both parents are unioned together. When a key is present in
+//     both parent trees, the left (first) context supercedes the right (second).
 //
 // All the normal context.With*() funcs should incorporate constexts correctly.
 //
@@ -33,28 +39,55 @@ type constext struct {
 // occur if both parents are Background, or were created only through
 // context.WithValue()), then the returned cancelFunc() is a no-op; calling it
 // will NOT result in the termination of any sub-contexts later created.
+//
+// Cons is a convenience wrapper around ConsAll for the common two-parent case.
 func Cons(l, r context.Context) (context.Context, context.CancelFunc) {
+	return ConsAll(l, r)
+}
+
+// ConsAll takes an arbitrary number of Contexts and combines them so that
+// they behave as one, conjoining their behavior the same way Cons does for
+// two parents:
+//
+//   - If any parent context is canceled, the constext is canceled. The err is
+//     set to whatever the err of the parent that was canceled.
+//   - If any parent has a deadline, the constext uses the soonest of them.
+//   - Values from all parents are unioned together, left to right - the
+//     first (left-most) parent whose tree holds a key supersedes the rest.
+//
+// Unlike nesting calls to Cons, ConsAll pairs all of its parents at once, so
+// conjoining N contexts costs a single constext instead of N-1 nested ones,
+// and Value() lookups walk a flat list instead of an N-deep tree of
+// constexts. See startWatch for how parent cancellation is observed.
+//
+// If all of the parent contexts return a nil channel from Done(), the
+// returned cancelFunc() is a no-op, per the same rule as Cons.
+func ConsAll(ctxs ...context.Context) (context.Context, context.CancelFunc) {
 	cc := &constext{
-		car: l,
-		cdr: r,
+		parents: ctxs,
 	}
 
-	if cc.car.Done() == nil && cc.cdr.Done() == nil {
-		// Both parents are un-cancelable, so it's more technically correct to
-		// return a no-op func here.
+	var anyDone bool
+	for _, p := range ctxs {
+		if p.Done() != nil {
+			anyDone = true
+			break
+		}
+	}
+	if !anyDone {
+		// None of the parents are cancelable, so it's more technically
+		// correct to return a no-op func here.
 		return cc, func() {}
 	}
 
 	// Only make a done chan if at least some parents are cancelable.
 	cc.done = make(chan struct{})
 
-	if cc.car.Err() != nil {
-		cc.err = cc.car.Err()
-		return cc, func() { cc.cancel(context.Canceled) }
-	}
-	if cc.cdr.Err() != nil {
-		cc.err = cc.cdr.Err()
-		return cc, func() { cc.cancel(context.Canceled) }
+	for _, p := range ctxs {
+		if err := p.Err(); err != nil {
+			cc.cancel(err)
+			return cc, func() { cc.cancel(context.Canceled) }
+		}
 	}
 
 	// If there's a deadline set, make sure we respect it.
@@ -66,16 +99,9 @@ func Cons(l, r context.Context) (context.Context, context.CancelFunc) {
 		}
 	}
 
-	go func() {
-		select {
-		case <-cc.car.Done():
-			cc.cancel(cc.car.Err())
-		case <-cc.cdr.Done():
-			cc.cancel(cc.cdr.Err())
-		}
-	}()
+	stop := cc.startWatch()
 
-	return cc, func() { cc.cancel(context.Canceled) }
+	return cc, func() { stop(); cc.cancel(context.Canceled) }
 }
 
 func (cc *constext) cancel(err error) {
@@ -83,38 +109,31 @@ func (cc *constext) cancel(err error) {
 		panic("constext: internal error: missing cancel error")
 	}
 
-	cc.mu.Lock()
-	if cc.err == nil {
-		cc.err = err
-		close(cc.done)
+	if cc.err.CompareAndSwap(nil, &err) {
+		cc.closeOnce.Do(func() { close(cc.done) })
 
-		if cc.timer != nil {
-			cc.timer.Stop()
-			cc.timer = nil
+		cc.restMu.Lock()
+		if cc.restCancel != nil {
+			cc.restCancel()
 		}
+		cc.restMu.Unlock()
 	}
-
-	cc.mu.Unlock()
 }
 
 func (cc *constext) Deadline() (time.Time, bool) {
-	hdeadline, hok := cc.car.Deadline()
-	tdeadline, tok := cc.cdr.Deadline()
-	if !hok && !tok {
-		return time.Time{}, false
-	}
-
-	if hok && !tok {
-		return hdeadline, true
-	}
-	if !hok && tok {
-		return tdeadline, true
-	}
-
-	if hdeadline.Before(tdeadline) {
-		return hdeadline, true
+	var deadline time.Time
+	var any bool
+	for _, p := range cc.parents {
+		dl, ok := p.Deadline()
+		if !ok {
+			continue
+		}
+		if !any || dl.Before(deadline) {
+			deadline = dl
+			any = true
+		}
 	}
-	return tdeadline, true
+	return deadline, any
 }
 
 func (cc *constext) Done() <-chan struct{} {
@@ -122,15 +141,76 @@ func (cc *constext) Done() <-chan struct{} {
 }
 
 func (cc *constext) Err() error {
-	cc.mu.Lock()
-	defer cc.mu.Unlock()
-	return cc.err
+	if err := cc.err.Load(); err != nil {
+		return *err
+	}
+	return nil
 }
 
 func (cc *constext) Value(key interface{}) interface{} {
-	v := cc.car.Value(key)
-	if v != nil {
-		return v
+	for _, p := range cc.parents {
+		// Background and TODO never hold any values - skip the call
+		// entirely rather than paying for the interface dispatch into
+		// the stdlib's own (trivial) Value method.
+		if p == context.Background() || p == context.TODO() {
+			continue
+		}
+		if v := p.Value(key); v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+// String renders cc similarly to how the stdlib's own context types render
+// themselves - e.g. "constext.Cons(context.Background, context.Background)"
+// - recursively formatting each parent via fmt.Sprint, so a tree of nested
+// constexts prints its whole shape.
+func (cc *constext) String() string {
+	name := "Cons"
+	if len(cc.parents) != 2 {
+		name = "ConsAll"
+	}
+
+	parts := make([]string, len(cc.parents))
+	for i, p := range cc.parents {
+		parts[i] = fmt.Sprint(p)
+	}
+
+	return fmt.Sprintf("constext.%s(%s)", name, strings.Join(parts, ", "))
+}
+
+// Parents returns the two contexts cc conjoins, so that tooling can walk a
+// tree of constexts programmatically. For the common two-parent case (via
+// Cons, or ConsAll with two arguments) this is exactly (car, cdr). For a
+// ConsAll of more than two contexts, the second return value is itself a
+// constext conjoining everything after the first, so the full N-way pairing
+// is still reachable by repeated calls to Parents.
+//
+// The synthesized rest constext for the N>2 case is built once and reused
+// across calls, and is canceled along with cc, so repeatedly walking the
+// tree doesn't accumulate watch registrations.
+func (cc *constext) Parents() (context.Context, context.Context) {
+	switch len(cc.parents) {
+	case 0:
+		return context.Background(), context.Background()
+	case 1:
+		return cc.parents[0], context.Background()
+	case 2:
+		return cc.parents[0], cc.parents[1]
+	default:
+		cc.restMu.Lock()
+		if cc.rest == nil {
+			cc.rest, cc.restCancel = ConsAll(cc.parents[1:]...)
+			if cc.Err() != nil {
+				// cc was already canceled before Parents() was first
+				// called, so cancel() will never observe this
+				// registration - cancel it ourselves instead.
+				cc.restCancel()
+			}
+		}
+		rest := cc.rest
+		cc.restMu.Unlock()
+		return cc.parents[0], rest
 	}
-	return cc.cdr.Value(key)
 }
@@ -0,0 +1,80 @@
+//go:build go1.20
+
+package constext
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// undoneParent hides the Done/Err of the embedded context from stdlib's own
+// context.WithCancelCause, so that the cancelCause context it produces has no
+// automatic propagation of its own racing against the explicit cancellation
+// paths ConsCause drives by hand below. Deadline and Value still delegate to
+// the embedded context as normal.
+type undoneParent struct {
+	context.Context
+}
+
+func (undoneParent) Done() <-chan struct{} { return nil }
+func (undoneParent) Err() error            { return nil }
+
+// ConsCause is like Cons, but returns a context.CancelCauseFunc instead of a
+// plain context.CancelFunc, and arranges for context.Cause to work against
+// the result the same way it does for context.WithCancelCause:
+//
+//   - If the returned CancelCauseFunc is called, context.Cause reports
+//     whatever cause was passed to it (a nil cause is replaced with
+//     context.Canceled, matching context.WithCancelCause).
+//   - If instead one of the two parents is what triggers cancellation,
+//     context.Cause reports that parent's own cause, falling back to its Err()
+//     if the parent isn't itself cause-aware.
+//
+// The concrete type returned is not *constext - it's a context.Context
+// derived from context.WithCancelCause, parented on the constext pairing l
+// and r - so that the stdlib's context.Cause can recognize it. Everything
+// else about the pairing (Deadline, Value union, Done/Err propagation) is
+// still handled by the underlying constext, same as plain Cons.
+func ConsCause(l, r context.Context) (context.Context, context.CancelCauseFunc) {
+	par, cancel := Cons(l, r)
+	cctx, cancelCause := context.WithCancelCause(undoneParent{par})
+
+	// applied guards which of the two paths below - a parent triggering
+	// cancellation, or the returned CancelCauseFunc being called directly -
+	// gets to supply the cause, so the two can race without a coin-flip over
+	// which cause cancelCause actually records.
+	var applied atomic.Bool
+
+	if par.Done() != nil {
+		go func() {
+			<-par.Done()
+			if !applied.CompareAndSwap(false, true) {
+				return
+			}
+
+			cause := par.Err()
+			if l.Err() != nil {
+				if c := context.Cause(l); c != nil {
+					cause = c
+				}
+			} else if r.Err() != nil {
+				if c := context.Cause(r); c != nil {
+					cause = c
+				}
+			}
+
+			cancelCause(cause)
+		}()
+	}
+
+	return cctx, func(cause error) {
+		// Claim applied before canceling par, so that if par has no other
+		// canceler, the goroutine above (once woken by cancel() below)
+		// always loses the race and defers to the cause given here.
+		won := applied.CompareAndSwap(false, true)
+		cancel()
+		if won {
+			cancelCause(cause)
+		}
+	}
+}
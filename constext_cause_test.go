@@ -0,0 +1,65 @@
+//go:build go1.20
+
+package constext
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestConsCauseExplicit(t *testing.T) {
+	// Run this several times: the explicit cause and ConsCause's own watcher
+	// goroutine on the constext parent both race to cancel the returned
+	// context, and that race could let the wrong cause win if unguarded.
+	for i := 0; i < 50; i++ {
+		c1, cancel1 := context.WithCancel(context.Background())
+		c2, cancel2 := context.WithCancel(context.Background())
+
+		uc, cancel := ConsCause(c1, c2)
+
+		myCause := errors.New("explicit cause")
+		cancel(myCause)
+
+		select {
+		case <-uc.Done():
+		case <-time.After(1 * time.Second):
+			buf := make([]byte, 10<<10)
+			n := runtime.Stack(buf, true)
+			t.Fatalf("timed out waiting for cancel; stacks:\n%s", buf[:n])
+		}
+
+		if got := context.Cause(uc); got != myCause {
+			t.Fatalf("wanted explicit cause %v, got %v", myCause, got)
+		}
+
+		cancel1()
+		cancel2()
+	}
+}
+
+func TestConsCauseFromParent(t *testing.T) {
+	parentCause := errors.New("parent cause")
+	c1, cancel1 := context.WithCancelCause(context.Background())
+	c2, cancel2 := context.WithCancel(context.Background())
+	defer cancel1(nil)
+	defer cancel2()
+
+	uc, _ := ConsCause(c1, c2)
+
+	cancel1(parentCause)
+
+	select {
+	case <-uc.Done():
+	case <-time.After(1 * time.Second):
+		buf := make([]byte, 10<<10)
+		n := runtime.Stack(buf, true)
+		t.Fatalf("timed out waiting for parent to quit; stacks:\n%s", buf[:n])
+	}
+
+	if got := context.Cause(uc); got != parentCause {
+		t.Fatalf("wanted parent cause %v, got %v", parentCause, got)
+	}
+}
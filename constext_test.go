@@ -0,0 +1,214 @@
+package constext
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestConsCancel(t *testing.T) {
+	c1, cancel1 := context.WithCancel(context.Background())
+	c2, cancel2 := context.WithCancel(context.Background())
+
+	uc, _ := Cons(c1, c2)
+	if _, has := uc.Deadline(); has {
+		t.Fatal("coalesce ctx should not have a deadline if parents do not")
+	}
+
+	cancel1()
+	select {
+	case <-uc.Done():
+	case <-time.After(1 * time.Second):
+		buf := make([]byte, 10<<10)
+		n := runtime.Stack(buf, true)
+		t.Fatalf("timed out waiting for parent to quit; stacks:\n%s", buf[:n])
+	}
+
+	uc, _ = Cons(c1, c2)
+	if uc.Err() == nil {
+		t.Fatal("pre-canceled (c1) coalesced context did not begin canceled")
+	}
+
+	uc, _ = Cons(c2, c1)
+	if uc.Err() == nil {
+		t.Fatal("pre-canceled (c2) coalesced context did not begin canceled")
+	}
+
+	c3, _ := context.WithCancel(context.Background())
+	uc, _ = Cons(c3, c2)
+	cancel2()
+	select {
+	case <-uc.Done():
+	case <-time.After(1 * time.Second):
+		buf := make([]byte, 10<<10)
+		n := runtime.Stack(buf, true)
+		t.Fatalf("timed out waiting for second parent to quit; stacks:\n%s", buf[:n])
+	}
+}
+
+func TestCancelPassdown(t *testing.T) {
+	c1, cancel1 := context.WithCancel(context.Background())
+	c2, _ := context.WithCancel(context.Background())
+	uc, _ := Cons(c1, c2)
+	c3, _ := context.WithCancel(uc)
+
+	cancel1()
+	select {
+	case <-c3.Done():
+	case <-time.After(1 * time.Second):
+		buf := make([]byte, 10<<10)
+		n := runtime.Stack(buf, true)
+		t.Fatalf("timed out waiting for parent to quit; stacks:\n%s", buf[:n])
+	}
+
+	c1, cancel1 = context.WithCancel(context.Background())
+	uc, _ = Cons(c1, c2)
+	c3 = context.WithValue(uc, "foo", "bar")
+
+	cancel1()
+	select {
+	case <-c3.Done():
+	case <-time.After(1 * time.Second):
+		buf := make([]byte, 10<<10)
+		n := runtime.Stack(buf, true)
+		t.Fatalf("timed out waiting for parent to quit; stacks:\n%s", buf[:n])
+	}
+}
+
+func TestConsAll(t *testing.T) {
+	c1, cancel1 := context.WithCancel(context.Background())
+	c2, cancel2 := context.WithCancel(context.Background())
+	c3, cancel3 := context.WithCancel(context.Background())
+	defer cancel1()
+	defer cancel2()
+	defer cancel3()
+
+	uc, _ := ConsAll(c1, c2, c3)
+	if _, has := uc.Deadline(); has {
+		t.Fatal("constext should not have a deadline if no parent does")
+	}
+
+	cancel2()
+	select {
+	case <-uc.Done():
+	case <-time.After(1 * time.Second):
+		buf := make([]byte, 10<<10)
+		n := runtime.Stack(buf, true)
+		t.Fatalf("timed out waiting for middle parent to quit; stacks:\n%s", buf[:n])
+	}
+	if uc.Err() != context.Canceled {
+		t.Fatalf("wanted Canceled err from the parent that quit, got %v", uc.Err())
+	}
+
+	c4, _ := context.WithCancel(context.Background())
+	uc, _ = ConsAll(c4, c2)
+	if uc.Err() == nil {
+		t.Fatal("pre-canceled (c2) constext did not begin canceled")
+	}
+	select {
+	case <-uc.Done():
+	default:
+		t.Fatal("pre-canceled constext should already have a closed Done() channel")
+	}
+}
+
+func TestConsAllValueUnion(t *testing.T) {
+	c1 := context.WithValue(context.Background(), "foo", "bar")
+	c2 := context.WithValue(context.Background(), "foo", "baz")
+	c3 := context.WithValue(context.Background(), "bar", "quux")
+
+	uc, _ := ConsAll(c1, c2, c3)
+	if v := uc.Value("foo"); v != "bar" {
+		t.Fatalf("wanted value of \"foo\" from first union member, \"bar\", got %q", v)
+	}
+	if v := uc.Value("bar"); v != "quux" {
+		t.Fatalf("wanted value from third union member, \"quux\", got %q", v)
+	}
+}
+
+func TestString(t *testing.T) {
+	uc, _ := Cons(context.Background(), context.Background())
+	want := "constext.Cons(context.Background, context.Background)"
+	if got := fmt.Sprint(uc); got != want {
+		t.Fatalf("wanted %q, got %q", want, got)
+	}
+
+	uc, _ = ConsAll(context.Background(), context.Background(), context.Background())
+	want = "constext.ConsAll(context.Background, context.Background, context.Background)"
+	if got := fmt.Sprint(uc); got != want {
+		t.Fatalf("wanted %q, got %q", want, got)
+	}
+}
+
+func TestParents(t *testing.T) {
+	c1 := context.WithValue(context.Background(), "foo", "bar")
+	c2 := context.WithValue(context.Background(), "foo", "baz")
+	uc, _ := Cons(c1, c2)
+
+	pcar, pcdr := uc.(interface {
+		Parents() (context.Context, context.Context)
+	}).Parents()
+	if pcar != c1 {
+		t.Fatalf("wanted car to be c1, got %v", pcar)
+	}
+	if pcdr != c2 {
+		t.Fatalf("wanted cdr to be c2, got %v", pcdr)
+	}
+}
+
+func TestParentsManyRepeated(t *testing.T) {
+	c1, cancel1 := context.WithCancel(context.Background())
+	c2, cancel2 := context.WithCancel(context.Background())
+	c3, cancel3 := context.WithCancel(context.Background())
+	defer cancel1()
+	defer cancel2()
+	defer cancel3()
+
+	uc, cancel := ConsAll(c1, c2, c3)
+	defer cancel()
+
+	type parenter interface {
+		Parents() (context.Context, context.Context)
+	}
+
+	_, rest1 := uc.(parenter).Parents()
+	_, rest2 := uc.(parenter).Parents()
+	if rest1 != rest2 {
+		t.Fatal("repeated calls to Parents() should return the same synthesized rest context")
+	}
+
+	cancel()
+	select {
+	case <-rest1.Done():
+	case <-time.After(1 * time.Second):
+		buf := make([]byte, 10<<10)
+		n := runtime.Stack(buf, true)
+		t.Fatalf("canceling the outer constext should cancel the synthesized rest too; stacks:\n%s", buf[:n])
+	}
+}
+
+func TestValueUnion(t *testing.T) {
+	c1 := context.WithValue(context.Background(), "foo", "bar")
+	c2 := context.WithValue(context.Background(), "foo", "baz")
+	uc, _ := Cons(c1, c2)
+
+	v := uc.Value("foo")
+	if v != "bar" {
+		t.Fatalf("wanted value of \"foo\" from first union member, \"bar\", got %q", v)
+	}
+
+	c3 := context.WithValue(context.Background(), "bar", "quux")
+	uc2, _ := Cons(c1, c3)
+	v = uc2.Value("bar")
+	if v != "quux" {
+		t.Fatalf("wanted value from c2, \"quux\", got %q", v)
+	}
+
+	uc, _ = Cons(uc, c3)
+	v = uc.Value("bar")
+	if v != "quux" {
+		t.Fatalf("wanted value from nested c2, \"quux\", got %q", v)
+	}
+}
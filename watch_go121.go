@@ -0,0 +1,37 @@
+//go:build go1.21
+
+package constext
+
+import "context"
+
+// startWatch registers a cancellation callback on each cancelable parent via
+// context.AfterFunc, rather than parking a goroutine in a select over every
+// parent's Done() channel. context.AfterFunc already avoids spawning a
+// goroutine at all when a parent is one of the stdlib's own cancelCtx-derived
+// contexts, since those contexts keep an internal list of such callbacks; for
+// any other context.Context implementation (including other constexts), it
+// falls back to one goroutine per registration.
+//
+// It returns a stop func that unregisters every callback, so that canceling
+// cc releases its hold on all of its parents instead of leaving the
+// registrations (and any fallback goroutines backing them) live until the
+// parents themselves finish.
+func (cc *constext) startWatch() func() {
+	stops := make([]func() bool, 0, len(cc.parents))
+	for _, p := range cc.parents {
+		if p.Done() == nil {
+			continue
+		}
+
+		p := p
+		stops = append(stops, context.AfterFunc(p, func() {
+			cc.cancel(p.Err())
+		}))
+	}
+
+	return func() {
+		for _, stop := range stops {
+			stop()
+		}
+	}
+}
@@ -0,0 +1,38 @@
+//go:build !go1.21
+
+package constext
+
+import "reflect"
+
+// startWatch fans in the Done() channels of all the parents, using
+// reflect.Select since the number of parents isn't known until runtime, and
+// cancels cc with the err of whichever parent finished first.
+//
+// This is the fallback used on Go versions before 1.21, which lack
+// context.AfterFunc; it costs one goroutine per constext regardless of how
+// many parents it has, parked until either a parent finishes or cc is
+// canceled directly. The returned stop func is a no-op, since there is no way
+// to unregister interest in the parents' Done() channels short of the
+// goroutine itself observing that cc is already done.
+func (cc *constext) startWatch() func() {
+	cases := make([]reflect.SelectCase, 0, len(cc.parents))
+	idx := make([]int, 0, len(cc.parents))
+	for i, p := range cc.parents {
+		if ch := p.Done(); ch != nil {
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ch)})
+			idx = append(idx, i)
+		}
+	}
+	cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(cc.done)})
+
+	go func() {
+		chosen, _, _ := reflect.Select(cases)
+		if chosen == len(cases)-1 {
+			// cc was already canceled directly; nothing left to watch for.
+			return
+		}
+		cc.cancel(cc.parents[idx[chosen]].Err())
+	}()
+
+	return func() {}
+}